@@ -0,0 +1,308 @@
+// Package adapter adapts the poloniex.Poloniex client to a small,
+// venue-neutral exchange interface so go-poloniex can be plugged into
+// multi-venue aggregation frameworks without callers writing their own
+// translation shims.
+package adapter
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/VisborN/go-poloniex"
+)
+
+// IBotExchange is the minimal surface a multi-venue framework needs from an
+// exchange client.
+type IBotExchange interface {
+	GetName() string
+	GetTicker(pair CurrencyPair) (Ticker, error)
+	GetOrderbook(pair CurrencyPair, depth int) (Depth, error)
+	GetKline(pair CurrencyPair, period KlinePeriod, start, end time.Time) ([]Kline, error)
+	GetAccountInfo() (Account, error)
+	SubmitOrder(pair CurrencyPair, side OrderSide, rate, amount float64) (Order, error)
+	CancelOrder(orderID string) error
+	GetOrderHistory(pair CurrencyPair) ([]Order, error)
+	GetDepositAddress(currency string) (string, error)
+	Withdraw(currency, address string, amount float64, tag string) (string, error)
+}
+
+// OrderSide is a normalized order side.
+type OrderSide string
+
+const (
+	Buy  OrderSide = "buy"
+	Sell OrderSide = "sell"
+)
+
+// KlinePeriod is a normalized candlestick period.
+type KlinePeriod time.Duration
+
+const (
+	Period5Min  KlinePeriod = KlinePeriod(5 * time.Minute)
+	Period15Min KlinePeriod = KlinePeriod(15 * time.Minute)
+	Period30Min KlinePeriod = KlinePeriod(30 * time.Minute)
+	Period2Hour KlinePeriod = KlinePeriod(2 * time.Hour)
+	Period4Hour KlinePeriod = KlinePeriod(4 * time.Hour)
+	Period1Day  KlinePeriod = KlinePeriod(24 * time.Hour)
+)
+
+// periodSeconds maps a normalized period to the seconds value Poloniex's
+// returnChartData endpoint expects.
+func periodSeconds(p KlinePeriod) int {
+	return int(time.Duration(p).Seconds())
+}
+
+// CurrencyPair is a venue-neutral base/quote pair.
+type CurrencyPair struct {
+	Base  string
+	Quote string
+}
+
+// String renders the pair in Poloniex's QUOTE_BASE wire format (e.g.
+// "BTC_ETH" for quote=BTC, base=ETH).
+func (p CurrencyPair) String() string {
+	return strings.ToUpper(p.Quote + "_" + p.Base)
+}
+
+// NewCurrencyPair normalizes a pair given in any of the common separator
+// styles into a CurrencyPair, reconciling their differing token orders so
+// that "BTC/ETH", "ETH-BTC" and "BTC_ETH" all denote the same market:
+// "/" and "_" are QUOTE/BASE (matching Poloniex's own QUOTE_BASE wire
+// convention), while "-" is the BASE-QUOTE order common to venues like
+// GDAX/Bitfinex.
+func NewCurrencyPair(s string) (CurrencyPair, error) {
+	var sep string
+	var dashed bool
+	switch {
+	case strings.Contains(s, "/"):
+		sep = "/"
+	case strings.Contains(s, "-"):
+		sep = "-"
+		dashed = true
+	case strings.Contains(s, "_"):
+		sep = "_"
+	default:
+		return CurrencyPair{}, fmt.Errorf("adapter: unrecognized currency pair %q", s)
+	}
+
+	parts := strings.SplitN(s, sep, 2)
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return CurrencyPair{}, fmt.Errorf("adapter: unrecognized currency pair %q", s)
+	}
+
+	if dashed {
+		return CurrencyPair{Base: strings.ToUpper(parts[0]), Quote: strings.ToUpper(parts[1])}, nil
+	}
+	return CurrencyPair{Quote: strings.ToUpper(parts[0]), Base: strings.ToUpper(parts[1])}, nil
+}
+
+// Ticker is a venue-neutral snapshot of a market's best prices.
+type Ticker struct {
+	Last float64
+	Bid  float64
+	Ask  float64
+}
+
+// Depth is a venue-neutral order book snapshot.
+type Depth struct {
+	Bids []DepthLevel
+	Asks []DepthLevel
+}
+
+// DepthLevel is a single price/size pair of a Depth side.
+type DepthLevel struct {
+	Price float64
+	Size  float64
+}
+
+// Kline is a venue-neutral candlestick.
+type Kline struct {
+	Time   time.Time
+	Open   float64
+	High   float64
+	Low    float64
+	Close  float64
+	Volume float64
+}
+
+// Account is a venue-neutral view of account balances.
+type Account struct {
+	Balances map[string]float64
+}
+
+// Order is a venue-neutral view of a placed or historical order.
+type Order struct {
+	ID     string
+	Pair   CurrencyPair
+	Side   OrderSide
+	Rate   float64
+	Amount float64
+	Total  float64
+}
+
+// Adapter implements IBotExchange on top of a poloniex.Poloniex client.
+type Adapter struct {
+	client *poloniex.Poloniex
+}
+
+// New returns an Adapter wrapping client.
+func New(client *poloniex.Poloniex) *Adapter {
+	return &Adapter{client: client}
+}
+
+// GetName returns the venue name.
+func (a *Adapter) GetName() string {
+	return "poloniex"
+}
+
+// GetTicker returns the current ticker for pair.
+func (a *Adapter) GetTicker(pair CurrencyPair) (Ticker, error) {
+	tickers, err := a.client.GetTickers()
+	if err != nil {
+		return Ticker{}, err
+	}
+	t, ok := tickers[pair.String()]
+	if !ok {
+		return Ticker{}, fmt.Errorf("adapter: unknown market %s", pair)
+	}
+	return Ticker{Last: t.Last, Bid: t.HighestBid, Ask: t.LowestAsk}, nil
+}
+
+// GetOrderbook returns the order book for pair, capped at depth levels per
+// side.
+func (a *Adapter) GetOrderbook(pair CurrencyPair, depth int) (Depth, error) {
+	book, err := a.client.GetOrderBook(pair.String(), "both", depth)
+	if err != nil {
+		return Depth{}, err
+	}
+
+	result := Depth{
+		Bids: make([]DepthLevel, 0, len(book.Bids)),
+		Asks: make([]DepthLevel, 0, len(book.Asks)),
+	}
+	for _, b := range book.Bids {
+		price, _ := b[0].Float64()
+		size, _ := b[1].Float64()
+		result.Bids = append(result.Bids, DepthLevel{Price: price, Size: size})
+	}
+	for _, ask := range book.Asks {
+		price, _ := ask[0].Float64()
+		size, _ := ask[1].Float64()
+		result.Asks = append(result.Asks, DepthLevel{Price: price, Size: size})
+	}
+	return result, nil
+}
+
+// GetKline returns candlesticks for pair between start and end.
+func (a *Adapter) GetKline(pair CurrencyPair, period KlinePeriod, start, end time.Time) ([]Kline, error) {
+	candles, err := a.client.ChartData(pair.String(), periodSeconds(period), start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	klines := make([]Kline, 0, len(candles))
+	for _, c := range candles {
+		klines = append(klines, Kline{
+			Time:   time.Unix(int64(c.Date), 0),
+			Open:   c.Open,
+			High:   c.High,
+			Low:    c.Low,
+			Close:  c.Close,
+			Volume: c.Volume,
+		})
+	}
+	return klines, nil
+}
+
+// GetAccountInfo returns the account's balances.
+func (a *Adapter) GetAccountInfo() (Account, error) {
+	balances, err := a.client.GetBalances()
+	if err != nil {
+		return Account{}, err
+	}
+
+	account := Account{Balances: make(map[string]float64, len(balances))}
+	for currency, balance := range balances {
+		account.Balances[currency] = balance.Available
+	}
+	return account, nil
+}
+
+// SubmitOrder places a buy or sell order on pair.
+func (a *Adapter) SubmitOrder(pair CurrencyPair, side OrderSide, rate, amount float64) (Order, error) {
+	var (
+		resp poloniex.TradeOrder
+		err  error
+	)
+	switch side {
+	case Buy:
+		resp, err = a.client.Buy(pair.String(), rate, amount, "")
+	case Sell:
+		resp, err = a.client.Sell(pair.String(), rate, amount, "")
+	default:
+		return Order{}, fmt.Errorf("adapter: unknown order side %q", side)
+	}
+	if err != nil {
+		return Order{}, err
+	}
+
+	return Order{
+		ID:     fmt.Sprintf("%d", resp.OrderNumber),
+		Pair:   pair,
+		Side:   side,
+		Rate:   rate,
+		Amount: amount,
+	}, nil
+}
+
+// CancelOrder cancels an open order by ID.
+func (a *Adapter) CancelOrder(orderID string) error {
+	var orderNumber int64
+	if _, err := fmt.Sscanf(orderID, "%d", &orderNumber); err != nil {
+		return fmt.Errorf("adapter: invalid order id %q: %w", orderID, err)
+	}
+	_, err := a.client.CancelOrder(orderNumber)
+	return err
+}
+
+// GetOrderHistory returns pair's completed trade history.
+func (a *Adapter) GetOrderHistory(pair CurrencyPair) ([]Order, error) {
+	trades, err := a.client.GetTradeHistory(pair.String(), 0)
+	if err != nil {
+		return nil, err
+	}
+
+	pairTrades := trades[pair.String()]
+	orders := make([]Order, 0, len(pairTrades))
+	for _, t := range pairTrades {
+		orders = append(orders, Order{
+			ID:     fmt.Sprintf("%d", t.GlobalTradeID),
+			Pair:   pair,
+			Side:   OrderSide(t.Type),
+			Rate:   t.Rate,
+			Amount: t.Amount,
+			Total:  t.Total,
+		})
+	}
+	return orders, nil
+}
+
+// GetDepositAddress returns the deposit address for currency, generating
+// one if none exists yet.
+func (a *Adapter) GetDepositAddress(currency string) (string, error) {
+	addresses, err := a.client.GetDepositAddresses()
+	if err != nil {
+		return "", err
+	}
+	if address, ok := addresses[strings.ToUpper(currency)]; ok {
+		return address, nil
+	}
+	return a.client.GenerateNewAddress(strings.ToUpper(currency))
+}
+
+// Withdraw withdraws amount of currency to address. tag is the
+// payment/destination tag required by some currencies.
+func (a *Adapter) Withdraw(currency, address string, amount float64, tag string) (string, error) {
+	return a.client.Withdraw(strings.ToUpper(currency), address, amount, tag)
+}
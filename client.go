@@ -0,0 +1,367 @@
+package poloniex
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"log"
+	"math/rand"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/websocket"
+)
+
+const (
+	tradingEndpoint = "tradingApi"
+)
+
+// NonceProvider produces the strictly increasing nonce required by
+// Poloniex's trading API. The default provider derives it from the wall
+// clock; pass a custom one via Config when running multiple processes
+// against the same API key.
+type NonceProvider interface {
+	Nonce() int64
+}
+
+// timeNonceProvider seeds from the wall clock but guarantees strict
+// monotonicity itself: two calls in the same millisecond (easily reached
+// under the trading rate limiter's default burst of 6) would otherwise
+// produce the same nonce, and Poloniex rejects a nonce that doesn't
+// strictly increase.
+type timeNonceProvider struct {
+	mu   sync.Mutex
+	last int64
+}
+
+func (p *timeNonceProvider) Nonce() int64 {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	n := time.Now().UnixNano() / int64(time.Millisecond)
+	if n <= p.last {
+		n = p.last + 1
+	}
+	p.last = n
+	return n
+}
+
+// RateLimiter throttles outgoing requests. Wait blocks until the caller is
+// allowed to proceed or ctx is done.
+type RateLimiter interface {
+	Wait(ctx context.Context) error
+}
+
+// tokenBucket is a minimal token-bucket RateLimiter: it allows burst
+// requests up to its capacity and refills at rate tokens/sec.
+type tokenBucket struct {
+	mu       sync.Mutex
+	tokens   float64
+	capacity float64
+	rate     float64
+	last     time.Time
+}
+
+// NewTokenBucket returns a RateLimiter allowing up to rate requests per
+// second, with bursts up to burst requests.
+func NewTokenBucket(rate float64, burst int) RateLimiter {
+	return &tokenBucket{
+		tokens:   float64(burst),
+		capacity: float64(burst),
+		rate:     rate,
+		last:     time.Now(),
+	}
+}
+
+func (tb *tokenBucket) Wait(ctx context.Context) error {
+	for {
+		tb.mu.Lock()
+		now := time.Now()
+		elapsed := now.Sub(tb.last).Seconds()
+		tb.last = now
+		tb.tokens += elapsed * tb.rate
+		if tb.tokens > tb.capacity {
+			tb.tokens = tb.capacity
+		}
+		if tb.tokens >= 1 {
+			tb.tokens--
+			tb.mu.Unlock()
+			return nil
+		}
+		wait := time.Duration((1 - tb.tokens) / tb.rate * float64(time.Second))
+		tb.mu.Unlock()
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// RetryPolicy controls how failed requests are retried.
+type RetryPolicy struct {
+	MaxAttempts int           // total attempts, including the first; 1 disables retries, 0 in a Config selects DefaultRetryPolicy
+	BaseDelay   time.Duration // delay before the first retry
+	MaxDelay    time.Duration // upper bound on the backoff delay
+}
+
+// DefaultRetryPolicy makes up to 3 attempts (2 retries) with exponential
+// backoff and jitter, starting at 250ms.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{MaxAttempts: 3, BaseDelay: 250 * time.Millisecond, MaxDelay: 5 * time.Second}
+}
+
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	d := p.BaseDelay * time.Duration(1<<uint(attempt))
+	if d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	jitter := time.Duration(rand.Int63n(int64(d) + 1))
+	return d/2 + jitter/2
+}
+
+func (p RetryPolicy) shouldRetry(attempt int, statusCode int, err error) bool {
+	if attempt+1 >= p.MaxAttempts {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	return statusCode == http.StatusTooManyRequests || statusCode >= 500
+}
+
+// nonIdempotentCommands lists tradingApi commands that create or move state
+// (place/modify an order, move funds, withdraw) and therefore must never be
+// retried automatically: a network error can occur after Poloniex has
+// already executed the command server-side but before the response reaches
+// the client, and resubmitting would risk a duplicate trade or withdrawal.
+var nonIdempotentCommands = map[string]bool{
+	"buy":                 true,
+	"sell":                true,
+	"marginBuy":           true,
+	"marginSell":          true,
+	"moveOrder":           true,
+	"withdraw":            true,
+	"createLoanOffer":     true,
+	"transferBalance":     true,
+	"closeMarginPosition": true,
+	"generateNewAddress":  true,
+}
+
+// Config customizes the HTTP transport used by a Poloniex client: the
+// round tripper, request timeout, rate limiting, retry behaviour and nonce
+// source.
+type Config struct {
+	Transport      http.RoundTripper
+	Timeout        time.Duration
+	PublicLimiter  RateLimiter
+	TradingLimiter RateLimiter
+	RetryPolicy    RetryPolicy
+	NonceProvider  NonceProvider
+}
+
+func (c Config) withDefaults() Config {
+	if c.Timeout == 0 {
+		c.Timeout = 30 * time.Second
+	}
+	if c.PublicLimiter == nil {
+		c.PublicLimiter = NewTokenBucket(6, 6)
+	}
+	if c.TradingLimiter == nil {
+		c.TradingLimiter = NewTokenBucket(6, 6)
+	}
+	if c.RetryPolicy.MaxAttempts == 0 {
+		c.RetryPolicy = DefaultRetryPolicy()
+	}
+	if c.NonceProvider == nil {
+		c.NonceProvider = &timeNonceProvider{}
+	}
+	return c
+}
+
+// client represent a poloniex client
+type client struct {
+	apiKey     string
+	apiSecret  string
+	httpClient *http.Client
+	debug      bool
+
+	publicLimiter  RateLimiter
+	tradingLimiter RateLimiter
+	retryPolicy    RetryPolicy
+	nonceProvider  NonceProvider
+
+	wsMu     sync.Mutex
+	wsConns  map[*websocket.Conn]bool
+	wsClosed bool
+}
+
+// NewClient returns a new client for the given API key/secret, using
+// default transport settings.
+func NewClient(apiKey, apiSecret string) *client {
+	return NewClientWithConfig(apiKey, apiSecret, Config{})
+}
+
+// NewClientWithCustomTimeout returns a new client with a custom request
+// timeout.
+func NewClientWithCustomTimeout(apiKey, apiSecret string, timeout time.Duration) *client {
+	return NewClientWithConfig(apiKey, apiSecret, Config{Timeout: timeout})
+}
+
+// NewClientWithConfig returns a new client using cfg to configure the
+// transport, rate limiting, retry policy and nonce source.
+func NewClientWithConfig(apiKey, apiSecret string, cfg Config) *client {
+	cfg = cfg.withDefaults()
+	return &client{
+		apiKey:    apiKey,
+		apiSecret: apiSecret,
+		httpClient: &http.Client{
+			Timeout:   cfg.Timeout,
+			Transport: cfg.Transport,
+		},
+		publicLimiter:  cfg.PublicLimiter,
+		tradingLimiter: cfg.TradingLimiter,
+		retryPolicy:    cfg.RetryPolicy,
+		nonceProvider:  cfg.NonceProvider,
+	}
+}
+
+// do performs a public (unsigned) request against endpoint, retrying on
+// transient failures; public GETs are idempotent and always safe to retry.
+func (c *client) do(method, endpoint string, values url.Values, sign bool) ([]byte, error) {
+	return c.doContext(context.Background(), method, endpoint, values, sign)
+}
+
+func (c *client) doContext(ctx context.Context, method, endpoint string, values url.Values, sign bool) ([]byte, error) {
+	return c.doContextRetryable(ctx, method, endpoint, values, sign, true)
+}
+
+// doContextRetryable is doContext with explicit control over whether a
+// failed request may be retried. Non-idempotent trading commands must pass
+// retryable=false: see nonIdempotentCommands.
+func (c *client) doContextRetryable(ctx context.Context, method, endpoint string, values url.Values, sign, retryable bool) ([]byte, error) {
+	limiter := c.publicLimiter
+	if sign {
+		limiter = c.tradingLimiter
+	}
+
+	var lastErr error
+	for attempt := 0; ; attempt++ {
+		if err := limiter.Wait(ctx); err != nil {
+			return nil, err
+		}
+
+		body, status, err := c.doOnce(ctx, method, endpoint, values, sign)
+		if err == nil && status < 400 {
+			return body, nil
+		}
+		lastErr = err
+		if lastErr == nil {
+			lastErr = fmt.Errorf("poloniex: unexpected status %d", status)
+		}
+		if !retryable || !c.retryPolicy.shouldRetry(attempt, status, err) {
+			return body, lastErr
+		}
+
+		timer := time.NewTimer(c.retryPolicy.delay(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *client) doOnce(ctx context.Context, method, endpoint string, values url.Values, sign bool) ([]byte, int, error) {
+	u := fmt.Sprintf("%s/%s", API_BASE, endpoint)
+
+	var req *http.Request
+	var err error
+	if sign {
+		if values == nil {
+			values = url.Values{}
+		}
+		values.Set("nonce", strconv.FormatInt(c.nonceProvider.Nonce(), 10))
+		req, err = http.NewRequest(method, fmt.Sprintf("%s/%s", API_BASE, tradingEndpoint), strings.NewReader(values.Encode()))
+		if err != nil {
+			return nil, 0, err
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("Key", c.apiKey)
+		req.Header.Set("Sign", c.sign(values.Encode()))
+	} else {
+		req, err = http.NewRequest(method, u, nil)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	req = req.WithContext(ctx)
+
+	if c.debug {
+		log.Printf("poloniex: %s %s", req.Method, req.URL)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, resp.StatusCode, err
+	}
+
+	if c.debug {
+		log.Printf("poloniex: response %d: %s", resp.StatusCode, body)
+	}
+
+	return body, resp.StatusCode, nil
+}
+
+func (c *client) sign(payload string) string {
+	mac := hmac.New(sha512.New, []byte(c.apiSecret))
+	mac.Write([]byte(payload))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// doCommand issues a signed request to the trading API for the given
+// command.
+func (c *client) doCommand(command string, params map[string]string) ([]byte, error) {
+	return c.doCommandContext(context.Background(), command, params)
+}
+
+func (c *client) doCommandContext(ctx context.Context, command string, params map[string]string) ([]byte, error) {
+	values := url.Values{}
+	values.Set("command", command)
+	for k, v := range params {
+		values.Set(k, v)
+	}
+
+	retryable := !nonIdempotentCommands[command]
+	r, err := c.doContextRetryable(ctx, "POST", tradingEndpoint, values, true, retryable)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiErr struct {
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(r, &apiErr); err == nil && apiErr.Error != "" {
+		return nil, fmt.Errorf("poloniex: %s", apiErr.Error)
+	}
+
+	return r, nil
+}
@@ -0,0 +1,356 @@
+package poloniex
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// NewWithConfig returns an instantiated poloniex struct using cfg to
+// configure the HTTP transport, rate limiting, retry policy and nonce
+// source.
+func NewWithConfig(apiKey, apiSecret string, cfg Config) *Poloniex {
+	client := NewClientWithConfig(apiKey, apiSecret, cfg)
+	return &Poloniex{client}
+}
+
+// GetTickersContext is the context-aware variant of GetTickers.
+func (b *Poloniex) GetTickersContext(ctx context.Context) (tickers map[string]Ticker, err error) {
+	r, err := b.client.doContext(ctx, "GET", "public?command=returnTicker", nil, false)
+	if err != nil {
+		return
+	}
+	if err = json.Unmarshal(r, &tickers); err != nil {
+		return
+	}
+	return
+}
+
+// GetVolumesContext is the context-aware variant of GetVolumes.
+func (b *Poloniex) GetVolumesContext(ctx context.Context) (vc VolumeCollection, err error) {
+	r, err := b.client.doContext(ctx, "GET", "public?command=return24hVolume", nil, false)
+	if err != nil {
+		return
+	}
+	if err = json.Unmarshal(r, &vc); err != nil {
+		return
+	}
+	return
+}
+
+// GetCurrenciesContext is the context-aware variant of GetCurrencies.
+func (b *Poloniex) GetCurrenciesContext(ctx context.Context) (currencies Currencies, err error) {
+	r, err := b.client.doContext(ctx, "GET", "public?command=returnCurrencies", nil, false)
+	if err != nil {
+		return
+	}
+	if err = json.Unmarshal(r, &currencies.Pair); err != nil {
+		return
+	}
+	return
+}
+
+// GetOrderBookContext is the context-aware variant of GetOrderBook.
+func (b *Poloniex) GetOrderBookContext(ctx context.Context, market, cat string, depth int) (orderBook OrderBook, err error) {
+	if cat != "bid" && cat != "ask" && cat != "both" {
+		cat = "both"
+	}
+	if depth > 100 {
+		depth = 100
+	}
+	if depth < 1 {
+		depth = 1
+	}
+
+	r, err := b.client.doContext(ctx, "GET", fmt.Sprintf("public?command=returnOrderBook&currencyPair=%s&depth=%d", strings.ToUpper(market), depth), nil, false)
+	if err != nil {
+		return
+	}
+	if err = json.Unmarshal(r, &orderBook); err != nil {
+		return
+	}
+	if orderBook.Error != "" {
+		err = errors.New(orderBook.Error)
+		return
+	}
+	return
+}
+
+// GetBalancesContext is the context-aware variant of GetBalances.
+func (b *Poloniex) GetBalancesContext(ctx context.Context) (balances map[string]Balance, err error) {
+	balances = make(map[string]Balance)
+	r, err := b.client.doCommandContext(ctx, "returnCompleteBalances", nil)
+	if err != nil {
+		return
+	}
+	if err = json.Unmarshal(r, &balances); err != nil {
+		return
+	}
+	return
+}
+
+// GetOpenOrdersContext is the context-aware variant of GetOpenOrders.
+func (b *Poloniex) GetOpenOrdersContext(ctx context.Context, pair string) (openOrders map[string][]OpenOrder, err error) {
+	openOrders = make(map[string][]OpenOrder)
+	r, err := b.client.doCommandContext(ctx, "returnOpenOrders", map[string]string{"currencyPair": pair})
+	if err != nil {
+		return
+	}
+	if pair == "all" {
+		if err = json.Unmarshal(r, &openOrders); err != nil {
+			return
+		}
+	} else {
+		var onePairOrders []OpenOrder
+		if err = json.Unmarshal(r, &onePairOrders); err != nil {
+			return
+		}
+		openOrders[pair] = onePairOrders
+	}
+	return
+}
+
+// BuyContext is the context-aware variant of Buy.
+func (b *Poloniex) BuyContext(ctx context.Context, pair string, rate float64, amount float64, tradeType string) (TradeOrder, error) {
+	reqParams := map[string]string{
+		"currencyPair": pair, "rate": strconv.FormatFloat(rate, 'f', -1, 64),
+		"amount": strconv.FormatFloat(amount, 'f', -1, 64)}
+	if tradeType != "" {
+		reqParams[tradeType] = "1"
+	}
+	r, err := b.client.doCommandContext(ctx, "buy", reqParams)
+	if err != nil {
+		return TradeOrder{}, err
+	}
+	var orderResponse TradeOrder
+	if err = json.Unmarshal(r, &orderResponse); err != nil {
+		return TradeOrder{}, err
+	}
+
+	return orderResponse, nil
+}
+
+// SellContext is the context-aware variant of Sell.
+func (b *Poloniex) SellContext(ctx context.Context, pair string, rate float64, amount float64, tradeType string) (TradeOrder, error) {
+	reqParams := map[string]string{
+		"currencyPair": pair, "rate": strconv.FormatFloat(rate, 'f', -1, 64),
+		"amount": strconv.FormatFloat(amount, 'f', -1, 64)}
+	if tradeType != "" {
+		reqParams[tradeType] = "1"
+	}
+	r, err := b.client.doCommandContext(ctx, "sell", reqParams)
+	if err != nil {
+		return TradeOrder{}, err
+	}
+	var orderResponse TradeOrder
+	if err = json.Unmarshal(r, &orderResponse); err != nil {
+		return TradeOrder{}, err
+	}
+
+	return orderResponse, nil
+}
+
+// CancelOrderContext is the context-aware variant of CancelOrder.
+func (b *Poloniex) CancelOrderContext(ctx context.Context, orderNumber int64) (bool, error) {
+	r, err := b.client.doCommandContext(ctx, "cancelOrder", map[string]string{"orderNumber": strconv.FormatInt(orderNumber, 10)})
+	if err != nil {
+		return false, err
+	}
+	var response struct {
+		Success int    `json:"success"`
+		Message string `json:"message"`
+	}
+	if err = json.Unmarshal(r, &response); err != nil {
+		return false, err
+	}
+
+	return response.Success == 1, nil
+}
+
+// WithdrawContext is the context-aware variant of Withdraw.
+func (b *Poloniex) WithdrawContext(ctx context.Context, currency, address string, amount float64, paymentID string) (string, error) {
+	reqParams := map[string]string{
+		"currency": currency,
+		"address":  address,
+		"amount":   strconv.FormatFloat(amount, 'f', -1, 64),
+	}
+	if paymentID != "" {
+		reqParams["paymentId"] = paymentID
+	}
+	r, err := b.client.doCommandContext(ctx, "withdraw", reqParams)
+	if err != nil {
+		return "", err
+	}
+	var response struct {
+		Response string `json:"response"`
+	}
+	if err = json.Unmarshal(r, &response); err != nil {
+		return "", err
+	}
+
+	return response.Response, nil
+}
+
+// GetAllOrderBookContext is the context-aware variant of GetAllOrderBook.
+func (b *Poloniex) GetAllOrderBookContext(ctx context.Context, cat string, depth int) (orderBook map[string]OrderBook, err error) {
+	if cat != "bid" && cat != "ask" && cat != "both" {
+		cat = "both"
+	}
+	if depth > 100 {
+		depth = 100
+	}
+	if depth < 1 {
+		depth = 1
+	}
+
+	r, err := b.client.doContext(ctx, "GET", fmt.Sprintf("public?command=returnOrderBook&currencyPair=all&depth=%d", depth), nil, false)
+	if err != nil {
+		return
+	}
+	if err = json.Unmarshal(r, &orderBook); err != nil {
+		return
+	}
+	return
+}
+
+// ChartDataContext is the context-aware variant of ChartData.
+func (b *Poloniex) ChartDataContext(ctx context.Context, currencyPair string, period int, start, end time.Time) (candles []*CandleStick, err error) {
+	r, err := b.client.doContext(ctx, "GET", fmt.Sprintf(
+		"public?command=returnChartData&currencyPair=%s&period=%d&start=%d&end=%d",
+		strings.ToUpper(currencyPair),
+		period,
+		start.Unix(),
+		end.Unix(),
+	), nil, false)
+	if err != nil {
+		return
+	}
+
+	if err = json.Unmarshal(r, &candles); err != nil {
+		return
+	}
+
+	return
+}
+
+// GetTradeHistoryContext is the context-aware variant of GetTradeHistory.
+func (b *Poloniex) GetTradeHistoryContext(ctx context.Context, pair string, start uint32) (trades map[string][]Trade, err error) {
+	trades = make(map[string][]Trade)
+	r, err := b.client.doCommandContext(ctx, "returnTradeHistory", map[string]string{"currencyPair": pair, "start": strconv.FormatUint(uint64(start), 10)})
+	if err != nil {
+		return
+	}
+
+	if pair == "all" {
+		if err = json.Unmarshal(r, &trades); err != nil {
+			return
+		}
+	} else {
+		var pairTrades []Trade
+		if err = json.Unmarshal(r, &pairTrades); err != nil {
+			return
+		}
+		trades[pair] = pairTrades
+	}
+
+	return
+}
+
+type responseDepositsWithdrawals struct {
+	Deposits    []Deposit    `json:"deposits"`
+	Withdrawals []Withdrawal `json:"withdrawals"`
+}
+
+// GetDepositsWithdrawalsContext is the context-aware variant of
+// GetDepositsWithdrawals.
+func (b *Poloniex) GetDepositsWithdrawalsContext(ctx context.Context, start uint32, end uint32) (deposits []Deposit, withdrawals []Withdrawal, err error) {
+	deposits = make([]Deposit, 0)
+	withdrawals = make([]Withdrawal, 0)
+	r, err := b.client.doCommandContext(ctx, "returnDepositsWithdrawals", map[string]string{"start": strconv.FormatUint(uint64(start), 10), "end": strconv.FormatUint(uint64(end), 10)})
+	if err != nil {
+		return
+	}
+	var response responseDepositsWithdrawals
+	if err = json.Unmarshal(r, &response); err != nil {
+		return
+	}
+
+	return response.Deposits, response.Withdrawals, nil
+}
+
+// MoveOrderContext is the context-aware variant of MoveOrder.
+func (b *Poloniex) MoveOrderContext(ctx context.Context, orderNumber int64, rate, amount float64, postOnly, immediateOrCancel bool) (TradeOrder, error) {
+	reqParams := map[string]string{
+		"orderNumber": strconv.FormatInt(orderNumber, 10),
+		"rate":        strconv.FormatFloat(rate, 'f', -1, 64),
+	}
+	if amount > 0 {
+		reqParams["amount"] = strconv.FormatFloat(amount, 'f', -1, 64)
+	}
+	if postOnly {
+		reqParams["postOnly"] = "1"
+	}
+	if immediateOrCancel {
+		reqParams["immediateOrCancel"] = "1"
+	}
+	r, err := b.client.doCommandContext(ctx, "moveOrder", reqParams)
+	if err != nil {
+		return TradeOrder{}, err
+	}
+	var orderResponse TradeOrder
+	if err = json.Unmarshal(r, &orderResponse); err != nil {
+		return TradeOrder{}, err
+	}
+
+	return orderResponse, nil
+}
+
+// GenerateNewAddressContext is the context-aware variant of
+// GenerateNewAddress.
+func (b *Poloniex) GenerateNewAddressContext(ctx context.Context, currency string) (string, error) {
+	r, err := b.client.doCommandContext(ctx, "generateNewAddress", map[string]string{"currency": currency})
+	if err != nil {
+		return "", err
+	}
+	var response struct {
+		Success  int    `json:"success"`
+		Response string `json:"response"`
+	}
+	if err = json.Unmarshal(r, &response); err != nil {
+		return "", err
+	}
+
+	return response.Response, nil
+}
+
+// GetDepositAddressesContext is the context-aware variant of
+// GetDepositAddresses.
+func (b *Poloniex) GetDepositAddressesContext(ctx context.Context) (addresses map[string]string, err error) {
+	addresses = make(map[string]string)
+	r, err := b.client.doCommandContext(ctx, "returnDepositAddresses", nil)
+	if err != nil {
+		return
+	}
+	if err = json.Unmarshal(r, &addresses); err != nil {
+		return
+	}
+	return
+}
+
+// GetFeesContext is the context-aware variant of GetFees.
+func (b *Poloniex) GetFeesContext(ctx context.Context) (Fees, error) {
+	r, err := b.client.doCommandContext(ctx, "returnFeeInfo", map[string]string{})
+	if err != nil {
+		return Fees{}, err
+	}
+	var orderResponse Fees
+	if err = json.Unmarshal(r, &orderResponse); err != nil {
+		return Fees{}, err
+	}
+
+	return orderResponse, nil
+}
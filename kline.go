@@ -0,0 +1,212 @@
+package poloniex
+
+import (
+	"fmt"
+	"time"
+)
+
+// KlinePeriod is a candlestick period accepted by ChartData, expressed in
+// seconds as required by the returnChartData endpoint.
+type KlinePeriod int
+
+const (
+	Period5Min  KlinePeriod = 300
+	Period15Min KlinePeriod = 900
+	Period30Min KlinePeriod = 1800
+	Period2Hour KlinePeriod = 7200
+	Period4Hour KlinePeriod = 14400
+	Period1Day  KlinePeriod = 86400
+)
+
+// Valid reports whether p is one of the periods accepted by Poloniex.
+func (p KlinePeriod) Valid() bool {
+	switch p {
+	case Period5Min, Period15Min, Period30Min, Period2Hour, Period4Hour, Period1Day:
+		return true
+	}
+	return false
+}
+
+// maxCandlesPerRequest is the number of points Poloniex returns per
+// returnChartData call before truncating the range.
+const maxCandlesPerRequest = 500
+
+// ChartDataPaged returns candlestick data for [start, end], transparently
+// splitting the range into multiple ChartData requests when it would
+// otherwise exceed the number of points Poloniex returns per call.
+func (b *Poloniex) ChartDataPaged(currencyPair string, period KlinePeriod, start, end time.Time) ([]*CandleStick, error) {
+	if !period.Valid() {
+		return nil, fmt.Errorf("poloniex: invalid kline period %d", period)
+	}
+
+	chunk := time.Duration(period) * time.Second * maxCandlesPerRequest
+	var candles []*CandleStick
+	var lastDate int64
+
+	for cursor := start; cursor.Before(end); cursor = cursor.Add(chunk) {
+		chunkEnd := cursor.Add(chunk)
+		if chunkEnd.After(end) {
+			chunkEnd = end
+		}
+
+		page, err := b.ChartData(currencyPair, int(period), cursor, chunkEnd)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, c := range page {
+			if int64(c.Date) <= lastDate {
+				continue
+			}
+			candles = append(candles, c)
+			lastDate = int64(c.Date)
+		}
+	}
+
+	return candles, nil
+}
+
+// ResampleCandles aggregates src, which must be ordered by time and use a
+// period evenly dividing target, into candles of the target period.
+func ResampleCandles(src []*CandleStick, target KlinePeriod) ([]*CandleStick, error) {
+	if !target.Valid() {
+		return nil, fmt.Errorf("poloniex: invalid kline period %d", target)
+	}
+	if len(src) == 0 {
+		return nil, nil
+	}
+
+	var result []*CandleStick
+	var bucket *CandleStick
+	var bucketStart int64
+	var volumeWeighted float64
+
+	flush := func() {
+		if bucket == nil {
+			return
+		}
+		if bucket.Volume > 0 {
+			bucket.WeightedAverage = volumeWeighted / bucket.Volume
+		}
+		result = append(result, bucket)
+	}
+
+	for _, c := range src {
+		start := (int64(c.Date) / int64(target)) * int64(target)
+		if bucket == nil || start != bucketStart {
+			flush()
+			cp := *c
+			bucket = &cp
+			bucket.Date = start
+			bucketStart = start
+			volumeWeighted = 0
+		} else {
+			if c.High > bucket.High {
+				bucket.High = c.High
+			}
+			if c.Low < bucket.Low {
+				bucket.Low = c.Low
+			}
+			bucket.Close = c.Close
+			bucket.Volume += c.Volume
+			bucket.QuoteVolume += c.QuoteVolume
+		}
+		volumeWeighted += c.WeightedAverage * c.Volume
+	}
+	flush()
+
+	return result, nil
+}
+
+// CandleBuilder assembles live candlesticks of a fixed period from a trade
+// stream, emitting each candle on Closed once a trade from a later period
+// arrives (or Run returns); no candle is emitted on a timer.
+type CandleBuilder struct {
+	period KlinePeriod
+	closed chan *CandleStick
+
+	current      *CandleStick
+	currentStart int64
+}
+
+// NewCandleBuilder returns a CandleBuilder for the given period, or an
+// error if period is not one of the enumerated KlinePeriod values.
+func NewCandleBuilder(period KlinePeriod) (*CandleBuilder, error) {
+	if !period.Valid() {
+		return nil, fmt.Errorf("poloniex: invalid kline period %d", period)
+	}
+	return &CandleBuilder{
+		period: period,
+		closed: make(chan *CandleStick, 16),
+	}, nil
+}
+
+// Closed returns the channel of candles closed as new trades roll the
+// builder past the current period's boundary.
+func (cb *CandleBuilder) Closed() <-chan *CandleStick {
+	return cb.closed
+}
+
+// Run consumes the trade updates on updatesCh and feeds the builder until
+// the channel is closed, flushing the in-progress candle and closing
+// Closed() before it returns. Pair it with Poloniex.SubscribeOrderBook,
+// whose updatesCh also carries trade events.
+func (cb *CandleBuilder) Run(updatesCh <-chan MarketUpd) {
+	defer close(cb.closed)
+
+	for upd := range updatesCh {
+		if upd.Type != "trade" {
+			continue
+		}
+		cb.add(upd)
+	}
+
+	if cb.current != nil {
+		cb.close()
+	}
+}
+
+func (cb *CandleBuilder) add(upd MarketUpd) {
+	rate, _ := upd.Rate.Float64()
+	amount, _ := upd.Amount.Float64()
+	bucketStart := (upd.Time.Unix() / int64(cb.period)) * int64(cb.period)
+
+	if cb.current == nil || bucketStart != cb.currentStart {
+		if cb.current != nil {
+			cb.close()
+		}
+		cb.current = &CandleStick{
+			Date:            bucketStart,
+			Open:            rate,
+			High:            rate,
+			Low:             rate,
+			Close:           rate,
+			Volume:          amount,
+			QuoteVolume:     amount * rate,
+			WeightedAverage: rate,
+		}
+		cb.currentStart = bucketStart
+		return
+	}
+
+	if rate > cb.current.High {
+		cb.current.High = rate
+	}
+	if rate < cb.current.Low {
+		cb.current.Low = rate
+	}
+	cb.current.Close = rate
+	cb.current.Volume += amount
+	cb.current.QuoteVolume += amount * rate
+	if cb.current.Volume > 0 {
+		cb.current.WeightedAverage = cb.current.QuoteVolume / cb.current.Volume
+	}
+}
+
+func (cb *CandleBuilder) close() {
+	select {
+	case cb.closed <- cb.current:
+	default:
+		// slow consumer: drop rather than block the feed
+	}
+}
@@ -0,0 +1,74 @@
+package poloniex
+
+import "context"
+
+// MarginAccountSummary holds the account-wide margin summary returned by
+// returnMarginAccountSummary.
+type MarginAccountSummary struct {
+	TotalValue         float64 `json:"totalValue,string"`
+	PL                 float64 `json:"pl,string"`
+	LendingFees        float64 `json:"lendingFees,string"`
+	NetValue           float64 `json:"netValue,string"`
+	TotalBorrowedValue float64 `json:"totalBorrowedValue,string"`
+	CurrentMargin      float64 `json:"currentMargin,string"`
+}
+
+// MarginBuy places a margin buy order on the given market.
+func (b *Poloniex) MarginBuy(pair string, rate float64, amount float64, lendingRate float64) (TradeOrder, error) {
+	return b.MarginBuyContext(context.Background(), pair, rate, amount, lendingRate)
+}
+
+// MarginSell places a margin sell order on the given market.
+func (b *Poloniex) MarginSell(pair string, rate float64, amount float64, lendingRate float64) (TradeOrder, error) {
+	return b.MarginSellContext(context.Background(), pair, rate, amount, lendingRate)
+}
+
+// GetMarginPosition returns the margin position for the given market, or for
+// all markets if pair is "all".
+func (b *Poloniex) GetMarginPosition(pair string) (positions map[string]MarginPosition, err error) {
+	return b.GetMarginPositionContext(context.Background(), pair)
+}
+
+// CloseMarginPosition closes the margin position for the given market.
+func (b *Poloniex) CloseMarginPosition(pair string) (TradeOrder, error) {
+	return b.CloseMarginPositionContext(context.Background(), pair)
+}
+
+// GetMarginAccountSummary returns a summary of the margin account.
+func (b *Poloniex) GetMarginAccountSummary() (MarginAccountSummary, error) {
+	return b.GetMarginAccountSummaryContext(context.Background())
+}
+
+// TransferBalance moves funds between the exchange, margin and lending
+// accounts. fromAccount/toAccount are one of "exchange", "margin" or
+// "lending".
+func (b *Poloniex) TransferBalance(currency string, amount float64, fromAccount, toAccount string) (bool, error) {
+	return b.TransferBalanceContext(context.Background(), currency, amount, fromAccount, toAccount)
+}
+
+// CreateLoanOffer creates a loan offer for the given currency.
+func (b *Poloniex) CreateLoanOffer(currency string, amount, lendingRate float64, duration int, autoRenew bool) (int64, error) {
+	return b.CreateLoanOfferContext(context.Background(), currency, amount, lendingRate, duration, autoRenew)
+}
+
+// CancelLoanOffer cancels a previously created loan offer.
+func (b *Poloniex) CancelLoanOffer(orderNumber int64) (bool, error) {
+	return b.CancelLoanOfferContext(context.Background(), orderNumber)
+}
+
+// GetOpenLoanOffers returns open loan offers, keyed by currency.
+func (b *Poloniex) GetOpenLoanOffers() (offers map[string][]LoanOffer, err error) {
+	return b.GetOpenLoanOffersContext(context.Background())
+}
+
+// GetActiveLoans returns currently active loans, split into "provided" and
+// "used" buckets, keyed by currency.
+func (b *Poloniex) GetActiveLoans() (provided, used map[string][]ActiveLoan, err error) {
+	return b.GetActiveLoansContext(context.Background())
+}
+
+// ReturnLendingHistory returns the lending history between start and end,
+// limited to limit records (0 for the API default).
+func (b *Poloniex) ReturnLendingHistory(start, end uint32, limit int) (history []LendingRecord, err error) {
+	return b.ReturnLendingHistoryContext(context.Background(), start, end, limit)
+}
@@ -0,0 +1,219 @@
+package poloniex
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+)
+
+// MarginBuyContext is the context-aware variant of MarginBuy.
+func (b *Poloniex) MarginBuyContext(ctx context.Context, pair string, rate float64, amount float64, lendingRate float64) (TradeOrder, error) {
+	reqParams := map[string]string{
+		"currencyPair": pair, "rate": strconv.FormatFloat(rate, 'f', -1, 64),
+		"amount": strconv.FormatFloat(amount, 'f', -1, 64)}
+	if lendingRate > 0 {
+		reqParams["lendingRate"] = strconv.FormatFloat(lendingRate, 'f', -1, 64)
+	}
+	r, err := b.client.doCommandContext(ctx, "marginBuy", reqParams)
+	if err != nil {
+		return TradeOrder{}, err
+	}
+	var orderResponse TradeOrder
+	if err = json.Unmarshal(r, &orderResponse); err != nil {
+		return TradeOrder{}, err
+	}
+
+	return orderResponse, nil
+}
+
+// MarginSellContext is the context-aware variant of MarginSell.
+func (b *Poloniex) MarginSellContext(ctx context.Context, pair string, rate float64, amount float64, lendingRate float64) (TradeOrder, error) {
+	reqParams := map[string]string{
+		"currencyPair": pair, "rate": strconv.FormatFloat(rate, 'f', -1, 64),
+		"amount": strconv.FormatFloat(amount, 'f', -1, 64)}
+	if lendingRate > 0 {
+		reqParams["lendingRate"] = strconv.FormatFloat(lendingRate, 'f', -1, 64)
+	}
+	r, err := b.client.doCommandContext(ctx, "marginSell", reqParams)
+	if err != nil {
+		return TradeOrder{}, err
+	}
+	var orderResponse TradeOrder
+	if err = json.Unmarshal(r, &orderResponse); err != nil {
+		return TradeOrder{}, err
+	}
+
+	return orderResponse, nil
+}
+
+// GetMarginPositionContext is the context-aware variant of
+// GetMarginPosition.
+func (b *Poloniex) GetMarginPositionContext(ctx context.Context, pair string) (positions map[string]MarginPosition, err error) {
+	positions = make(map[string]MarginPosition)
+	r, err := b.client.doCommandContext(ctx, "getMarginPosition", map[string]string{"currencyPair": pair})
+	if err != nil {
+		return
+	}
+	if pair == "all" {
+		if err = json.Unmarshal(r, &positions); err != nil {
+			return
+		}
+	} else {
+		var position MarginPosition
+		if err = json.Unmarshal(r, &position); err != nil {
+			return
+		}
+		positions[pair] = position
+	}
+	return
+}
+
+// CloseMarginPositionContext is the context-aware variant of
+// CloseMarginPosition.
+func (b *Poloniex) CloseMarginPositionContext(ctx context.Context, pair string) (TradeOrder, error) {
+	r, err := b.client.doCommandContext(ctx, "closeMarginPosition", map[string]string{"currencyPair": pair})
+	if err != nil {
+		return TradeOrder{}, err
+	}
+	var orderResponse TradeOrder
+	if err = json.Unmarshal(r, &orderResponse); err != nil {
+		return TradeOrder{}, err
+	}
+
+	return orderResponse, nil
+}
+
+// GetMarginAccountSummaryContext is the context-aware variant of
+// GetMarginAccountSummary.
+func (b *Poloniex) GetMarginAccountSummaryContext(ctx context.Context) (MarginAccountSummary, error) {
+	r, err := b.client.doCommandContext(ctx, "returnMarginAccountSummary", nil)
+	if err != nil {
+		return MarginAccountSummary{}, err
+	}
+	var summary MarginAccountSummary
+	if err = json.Unmarshal(r, &summary); err != nil {
+		return MarginAccountSummary{}, err
+	}
+
+	return summary, nil
+}
+
+// TransferBalanceContext is the context-aware variant of TransferBalance.
+func (b *Poloniex) TransferBalanceContext(ctx context.Context, currency string, amount float64, fromAccount, toAccount string) (bool, error) {
+	reqParams := map[string]string{
+		"currency":    currency,
+		"amount":      strconv.FormatFloat(amount, 'f', -1, 64),
+		"fromAccount": fromAccount,
+		"toAccount":   toAccount,
+	}
+	r, err := b.client.doCommandContext(ctx, "transferBalance", reqParams)
+	if err != nil {
+		return false, err
+	}
+	var response struct {
+		Success int    `json:"success"`
+		Message string `json:"message"`
+	}
+	if err = json.Unmarshal(r, &response); err != nil {
+		return false, err
+	}
+
+	return response.Success == 1, nil
+}
+
+// CreateLoanOfferContext is the context-aware variant of CreateLoanOffer.
+func (b *Poloniex) CreateLoanOfferContext(ctx context.Context, currency string, amount, lendingRate float64, duration int, autoRenew bool) (int64, error) {
+	reqParams := map[string]string{
+		"currency":    currency,
+		"amount":      strconv.FormatFloat(amount, 'f', -1, 64),
+		"lendingRate": strconv.FormatFloat(lendingRate, 'f', -1, 64),
+		"duration":    strconv.Itoa(duration),
+	}
+	if autoRenew {
+		reqParams["autoRenew"] = "1"
+	} else {
+		reqParams["autoRenew"] = "0"
+	}
+	r, err := b.client.doCommandContext(ctx, "createLoanOffer", reqParams)
+	if err != nil {
+		return 0, err
+	}
+	var response struct {
+		Success int    `json:"success"`
+		Message string `json:"message"`
+		OrderID int64  `json:"orderID"`
+	}
+	if err = json.Unmarshal(r, &response); err != nil {
+		return 0, err
+	}
+
+	return response.OrderID, nil
+}
+
+// CancelLoanOfferContext is the context-aware variant of CancelLoanOffer.
+func (b *Poloniex) CancelLoanOfferContext(ctx context.Context, orderNumber int64) (bool, error) {
+	r, err := b.client.doCommandContext(ctx, "cancelLoanOffer", map[string]string{"orderNumber": strconv.FormatInt(orderNumber, 10)})
+	if err != nil {
+		return false, err
+	}
+	var response struct {
+		Success int    `json:"success"`
+		Message string `json:"message"`
+	}
+	if err = json.Unmarshal(r, &response); err != nil {
+		return false, err
+	}
+
+	return response.Success == 1, nil
+}
+
+// GetOpenLoanOffersContext is the context-aware variant of
+// GetOpenLoanOffers.
+func (b *Poloniex) GetOpenLoanOffersContext(ctx context.Context) (offers map[string][]LoanOffer, err error) {
+	offers = make(map[string][]LoanOffer)
+	r, err := b.client.doCommandContext(ctx, "returnOpenLoanOffers", nil)
+	if err != nil {
+		return
+	}
+	if err = json.Unmarshal(r, &offers); err != nil {
+		return
+	}
+	return
+}
+
+// GetActiveLoansContext is the context-aware variant of GetActiveLoans.
+func (b *Poloniex) GetActiveLoansContext(ctx context.Context) (provided, used map[string][]ActiveLoan, err error) {
+	r, err := b.client.doCommandContext(ctx, "returnActiveLoans", nil)
+	if err != nil {
+		return
+	}
+	var response struct {
+		Provided map[string][]ActiveLoan `json:"provided"`
+		Used     map[string][]ActiveLoan `json:"used"`
+	}
+	if err = json.Unmarshal(r, &response); err != nil {
+		return
+	}
+
+	return response.Provided, response.Used, nil
+}
+
+// ReturnLendingHistoryContext is the context-aware variant of
+// ReturnLendingHistory.
+func (b *Poloniex) ReturnLendingHistoryContext(ctx context.Context, start, end uint32, limit int) (history []LendingRecord, err error) {
+	reqParams := map[string]string{
+		"start": strconv.FormatUint(uint64(start), 10),
+		"end":   strconv.FormatUint(uint64(end), 10),
+	}
+	if limit > 0 {
+		reqParams["limit"] = strconv.Itoa(limit)
+	}
+	r, err := b.client.doCommandContext(ctx, "returnLendingHistory", reqParams)
+	if err != nil {
+		return
+	}
+	if err = json.Unmarshal(r, &history); err != nil {
+		return
+	}
+	return
+}
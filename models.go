@@ -0,0 +1,148 @@
+package poloniex
+
+import (
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Ticker is a single market's entry in the response of returnTicker.
+type Ticker struct {
+	ID            int     `json:"id"`
+	Last          float64 `json:"last,string"`
+	LowestAsk     float64 `json:"lowestAsk,string"`
+	HighestBid    float64 `json:"highestBid,string"`
+	PercentChange float64 `json:"percentChange,string"`
+	BaseVolume    float64 `json:"baseVolume,string"`
+	QuoteVolume   float64 `json:"quoteVolume,string"`
+	IsFrozen      int     `json:"isFrozen,string"`
+	High24hr      float64 `json:"high24hr,string"`
+	Low24hr       float64 `json:"low24hr,string"`
+}
+
+// VolumeCollection is the response of return24hVolume: per-market volumes
+// plus a handful of totalXXX keys, all keyed by currency code.
+type VolumeCollection map[string]map[string]string
+
+// Currency describes a single currency's metadata, as returned by
+// returnCurrencies.
+type Currency struct {
+	ID             int     `json:"id"`
+	Name           string  `json:"name"`
+	TxFee          float64 `json:"txFee,string"`
+	MinConf        int     `json:"minConf"`
+	DepositAddress string  `json:"depositAddress"`
+	Disabled       int     `json:"disabled"`
+	Delisted       int     `json:"delisted"`
+	Frozen         int     `json:"frozen"`
+}
+
+// Currencies is the response of returnCurrencies, keyed by currency code.
+type Currencies struct {
+	Pair map[string]Currency
+}
+
+// Balance is a single currency's balance, as returned by
+// returnCompleteBalances.
+type Balance struct {
+	Available float64 `json:"available,string"`
+	OnOrders  float64 `json:"onOrders,string"`
+	BtcValue  float64 `json:"btcValue,string"`
+}
+
+// ResultingTrade is one of the fills that immediately resulted from placing
+// an order.
+type ResultingTrade struct {
+	Amount  float64 `json:"amount,string"`
+	Date    string  `json:"date"`
+	Rate    float64 `json:"rate,string"`
+	Total   float64 `json:"total,string"`
+	TradeID int64   `json:"tradeID,string"`
+	Type    string  `json:"type"`
+}
+
+// TradeOrder is the response to placing, moving, or closing an order.
+type TradeOrder struct {
+	OrderNumber     int64            `json:"orderNumber,string"`
+	ResultingTrades []ResultingTrade `json:"resultingTrades"`
+}
+
+// Trade is a single historical trade, as returned by returnTradeHistory.
+type Trade struct {
+	GlobalTradeID int64   `json:"globalTradeID"`
+	TradeID       int64   `json:"tradeID,string"`
+	Date          string  `json:"date"`
+	Type          string  `json:"type"`
+	Rate          float64 `json:"rate,string"`
+	Amount        float64 `json:"amount,string"`
+	Total         float64 `json:"total,string"`
+}
+
+// Fees is the response of returnFeeInfo.
+type Fees struct {
+	MakerFee        float64 `json:"makerFee,string"`
+	TakerFee        float64 `json:"takerFee,string"`
+	ThirtyDayVolume float64 `json:"thirtyDayVolume,string"`
+	NextTier        float64 `json:"nextTier,string"`
+}
+
+// CandleStick is a single candlestick returned by returnChartData.
+type CandleStick struct {
+	Date            int64   `json:"date"`
+	High            float64 `json:"high"`
+	Low             float64 `json:"low"`
+	Open            float64 `json:"open"`
+	Close           float64 `json:"close"`
+	Volume          float64 `json:"volume"`
+	QuoteVolume     float64 `json:"quoteVolume"`
+	WeightedAverage float64 `json:"weightedAverage"`
+}
+
+// Deposit is a single deposit, as returned by returnDepositsWithdrawals.
+type Deposit struct {
+	Currency      string  `json:"currency"`
+	Address       string  `json:"address"`
+	Amount        float64 `json:"amount,string"`
+	Confirmations int     `json:"confirmations"`
+	TxID          string  `json:"txid"`
+	Timestamp     int64   `json:"timestamp"`
+	Status        string  `json:"status"`
+}
+
+// Withdrawal is a single withdrawal, as returned by
+// returnDepositsWithdrawals.
+type Withdrawal struct {
+	WithdrawalNumber int64   `json:"withdrawalNumber"`
+	Currency         string  `json:"currency"`
+	Address          string  `json:"address"`
+	Amount           float64 `json:"amount,string"`
+	Timestamp        int64   `json:"timestamp"`
+	Status           string  `json:"status"`
+	IPAddress        string  `json:"ipAddress"`
+}
+
+// MarketUpd is a single order book or trade update delivered over the WAMP
+// feed by SubscribeOrderBook. Type is one of "bid", "ask" or "trade"; Amount
+// is zero for a removed price level.
+type MarketUpd struct {
+	Seq    int
+	Type   string
+	Rate   decimal.Decimal
+	Amount decimal.Decimal
+	Time   time.Time
+}
+
+// TickerUpd is a single ticker update delivered over the WAMP feed by
+// SubscribeTicker.
+type TickerUpd struct {
+	Pair          string
+	Last          decimal.Decimal
+	LowestAsk     decimal.Decimal
+	HighestBid    decimal.Decimal
+	PercentChange decimal.Decimal
+	BaseVolume    decimal.Decimal
+	QuoteVolume   decimal.Decimal
+	IsFrozen      bool
+	High24hr      decimal.Decimal
+	Low24hr       decimal.Decimal
+}
@@ -30,3 +30,53 @@ type OpenOrder struct {
 	Amount      float64 `json:"amount,string"`
 	Total       float64 `json:"total,string"`
 }
+
+// MarginPosition describes an open margin position for a single market.
+type MarginPosition struct {
+	Amount           float64 `json:"amount,string"`
+	Total            float64 `json:"total,string"`
+	BasePrice        float64 `json:"basePrice,string"`
+	LiquidationPrice float64 `json:"liquidationPrice,string"`
+	PL               float64 `json:"pl,string"`
+	LendingFees      float64 `json:"lendingFees,string"`
+	Type             string  `json:"type"`
+}
+
+// LoanOffer describes an offer to lend a currency, as listed by
+// returnOpenLoanOffers.
+type LoanOffer struct {
+	ID        int64   `json:"id"`
+	Rate      float64 `json:"rate,string"`
+	Amount    float64 `json:"amount,string"`
+	Duration  int     `json:"duration"`
+	AutoRenew int     `json:"autoRenew"`
+	Date      string  `json:"date"`
+}
+
+// ActiveLoan describes a loan that is currently lent out or borrowed, as
+// returned by returnActiveLoans.
+type ActiveLoan struct {
+	ID           int64   `json:"id"`
+	CurrencyPair string  `json:"currencyPair"`
+	Rate         float64 `json:"rate,string"`
+	Amount       float64 `json:"amount,string"`
+	Range        int     `json:"range"`
+	AutoRenew    int     `json:"autoRenew"`
+	Date         string  `json:"date"`
+	Fees         float64 `json:"fees,string"`
+}
+
+// LendingRecord is a single entry of lending history, as returned by
+// returnLendingHistory.
+type LendingRecord struct {
+	ID       int64   `json:"id"`
+	Currency string  `json:"currency"`
+	Rate     float64 `json:"rate,string"`
+	Amount   float64 `json:"amount,string"`
+	Duration float64 `json:"duration,string"`
+	Interest float64 `json:"interest,string"`
+	Fee      float64 `json:"fee,string"`
+	EarnedAt string  `json:"earned,string"`
+	Open     string  `json:"open"`
+	Close    string  `json:"close"`
+}
@@ -0,0 +1,319 @@
+package poloniex
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// BookEventType identifies the kind of update carried by a BookEvent.
+type BookEventType int
+
+const (
+	// Snapshot is emitted once, right after a LocalOrderBook has (re)synced
+	// against the REST API.
+	Snapshot BookEventType = iota
+	// Delta is emitted for every order book update applied on top of the
+	// current snapshot.
+	Delta
+	// TradeEvent is emitted for executed trades seen on the feed.
+	TradeEvent
+)
+
+// PriceLevel is a single price/size pair of an order book side.
+type PriceLevel struct {
+	Price decimal.Decimal
+	Size  decimal.Decimal
+}
+
+// BookEvent is published on LocalOrderBook's event channel for consumers
+// that want stream semantics on top of the maintained book.
+type BookEvent struct {
+	Symbol string
+	Seq    int
+	Type   BookEventType
+}
+
+// LocalOrderBook maintains a continuously-synchronized full order book for
+// a single market on top of SubscribeOrderBook, resyncing from the REST API
+// whenever a gap is detected in the update sequence.
+type LocalOrderBook struct {
+	symbol string
+	client *Poloniex
+
+	mu   sync.RWMutex
+	seq  int
+	bids map[string]PriceLevel
+	asks map[string]PriceLevel
+
+	events chan BookEvent
+	stopCh chan bool
+	doneCh chan struct{}
+}
+
+// SubscribeLocalOrderBook opens a local order book for symbol and starts
+// maintaining it in the background. Call Close to stop.
+//
+// The WS subscription is started before the REST snapshot is fetched, so
+// updates racing the snapshot are buffered rather than missed; run then
+// discards whatever the snapshot already covers.
+func (b *Poloniex) SubscribeLocalOrderBook(symbol string) (*LocalOrderBook, error) {
+	lob := &LocalOrderBook{
+		symbol: symbol,
+		client: b,
+		bids:   make(map[string]PriceLevel),
+		asks:   make(map[string]PriceLevel),
+		events: make(chan BookEvent, 64),
+		stopCh: make(chan bool),
+		doneCh: make(chan struct{}),
+	}
+
+	updatesCh := make(chan MarketUpd, 256)
+	go func() {
+		_ = lob.client.SubscribeOrderBook(symbol, updatesCh, lob.stopCh)
+	}()
+
+	if err := lob.resync(); err != nil {
+		close(lob.stopCh)
+		return nil, err
+	}
+
+	go lob.run(updatesCh)
+
+	return lob, nil
+}
+
+// Events returns the channel of BookEvent notifications for this book.
+func (lob *LocalOrderBook) Events() <-chan BookEvent {
+	return lob.events
+}
+
+// Close stops maintaining the book, releases the underlying subscription
+// and waits for the maintainer goroutine to exit.
+func (lob *LocalOrderBook) Close() {
+	close(lob.stopCh)
+	<-lob.doneCh
+}
+
+// Snapshot returns the current state of the book as sorted bid/ask slices,
+// best price first.
+func (lob *LocalOrderBook) Snapshot() (bids, asks []PriceLevel) {
+	lob.mu.RLock()
+	defer lob.mu.RUnlock()
+
+	bids = sortedLevels(lob.bids, true)
+	asks = sortedLevels(lob.asks, false)
+	return
+}
+
+// BestBid returns the highest bid currently known, or ok=false if the book
+// is empty.
+func (lob *LocalOrderBook) BestBid() (level PriceLevel, ok bool) {
+	lob.mu.RLock()
+	defer lob.mu.RUnlock()
+
+	levels := sortedLevels(lob.bids, true)
+	if len(levels) == 0 {
+		return PriceLevel{}, false
+	}
+	return levels[0], true
+}
+
+// BestAsk returns the lowest ask currently known, or ok=false if the book
+// is empty.
+func (lob *LocalOrderBook) BestAsk() (level PriceLevel, ok bool) {
+	lob.mu.RLock()
+	defer lob.mu.RUnlock()
+
+	levels := sortedLevels(lob.asks, false)
+	if len(levels) == 0 {
+		return PriceLevel{}, false
+	}
+	return levels[0], true
+}
+
+func sortedLevels(m map[string]PriceLevel, desc bool) []PriceLevel {
+	levels := make([]PriceLevel, 0, len(m))
+	for _, l := range m {
+		levels = append(levels, l)
+	}
+	sort.Slice(levels, func(i, j int) bool {
+		if desc {
+			return levels[i].Price.GreaterThan(levels[j].Price)
+		}
+		return levels[i].Price.LessThan(levels[j].Price)
+	})
+	return levels
+}
+
+// resync fetches a fresh REST snapshot and resets the in-memory book.
+func (lob *LocalOrderBook) resync() error {
+	book, err := lob.client.GetOrderBook(lob.symbol, "both", 100)
+	if err != nil {
+		return err
+	}
+
+	lob.mu.Lock()
+	lob.bids = make(map[string]PriceLevel, len(book.Bids))
+	lob.asks = make(map[string]PriceLevel, len(book.Asks))
+	for _, b := range book.Bids {
+		lob.setLevel(lob.bids, b[0], b[1])
+	}
+	for _, a := range book.Asks {
+		lob.setLevel(lob.asks, a[0], a[1])
+	}
+	lob.seq = book.Seq
+	lob.mu.Unlock()
+
+	lob.publish(BookEvent{Symbol: lob.symbol, Seq: book.Seq, Type: Snapshot})
+	return nil
+}
+
+func (lob *LocalOrderBook) setLevel(side map[string]PriceLevel, price, size decimal.Decimal) {
+	key := price.String()
+	if size.IsZero() {
+		delete(side, key)
+		return
+	}
+	side[key] = PriceLevel{Price: price, Size: size}
+}
+
+// gapResyncTimeout bounds how long run waits for a buffered seq gap to
+// close on its own (e.g. because the WS subscription raced the initial
+// REST snapshot) before forcing a full resync.
+const gapResyncTimeout = 2 * time.Second
+
+// run buffers incoming updates by seq, discards anything already covered by
+// the current snapshot, and applies the rest in order. A gap that doesn't
+// close within gapResyncTimeout triggers a full resync, whether or not
+// further updates arrive in the meantime. It returns, closing doneCh, once
+// stopCh is closed.
+func (lob *LocalOrderBook) run(updatesCh <-chan MarketUpd) {
+	defer close(lob.doneCh)
+
+	var buffer []MarketUpd
+	var gapTimer *time.Timer
+
+	for {
+		var timerC <-chan time.Time
+		if gapTimer != nil {
+			timerC = gapTimer.C
+		}
+
+		select {
+		case <-lob.stopCh:
+			return
+
+		case upd, ok := <-updatesCh:
+			if !ok {
+				return
+			}
+
+			lob.mu.RLock()
+			snapSeq := lob.seq
+			lob.mu.RUnlock()
+			if upd.Seq <= snapSeq {
+				continue
+			}
+			// insert in seq order (after any equal seq) so a late
+			// lower-seq update can close a gap at the buffer's head
+			i := sort.Search(len(buffer), func(i int) bool { return buffer[i].Seq > upd.Seq })
+			buffer = append(buffer, MarketUpd{})
+			copy(buffer[i+1:], buffer[i:])
+			buffer[i] = upd
+			buffer = lob.drainBuffer(buffer)
+
+			if lob.hasGap(buffer) {
+				if gapTimer == nil {
+					gapTimer = time.NewTimer(gapResyncTimeout)
+				}
+			} else if gapTimer != nil {
+				gapTimer.Stop()
+				gapTimer = nil
+			}
+
+		case <-timerC:
+			gapTimer = nil
+			if err := lob.resync(); err == nil {
+				buffer = nil
+			} else {
+				// keep retrying on the same cadence until it succeeds
+				gapTimer = time.NewTimer(gapResyncTimeout)
+			}
+		}
+	}
+}
+
+// drainBuffer applies every buffered update that is immediately next in
+// sequence, dropping anything already covered by the current snapshot, and
+// returns what's left once it hits a gap.
+func (lob *LocalOrderBook) drainBuffer(buffer []MarketUpd) []MarketUpd {
+	for len(buffer) > 0 {
+		lob.mu.RLock()
+		seq := lob.seq
+		lob.mu.RUnlock()
+
+		next := buffer[0]
+		if next.Seq <= seq {
+			buffer = buffer[1:]
+			continue
+		}
+		if next.Seq != seq+1 {
+			break
+		}
+		if !lob.apply(next) {
+			break
+		}
+		buffer = buffer[1:]
+	}
+	return buffer
+}
+
+// hasGap reports whether buffer's head update is not immediately next in
+// sequence, meaning an update was missed on the feed.
+func (lob *LocalOrderBook) hasGap(buffer []MarketUpd) bool {
+	if len(buffer) == 0 {
+		return false
+	}
+	lob.mu.RLock()
+	seq := lob.seq
+	lob.mu.RUnlock()
+	return buffer[0].Seq != seq+1
+}
+
+// apply applies a single update to the book. It returns false if the update
+// could not be applied (e.g. it is out of order), signalling the caller to
+// resync.
+func (lob *LocalOrderBook) apply(upd MarketUpd) bool {
+	lob.mu.Lock()
+	defer lob.mu.Unlock()
+
+	if upd.Seq != lob.seq+1 {
+		return false
+	}
+
+	switch upd.Type {
+	case "bid":
+		lob.setLevel(lob.bids, upd.Rate, upd.Amount)
+	case "ask":
+		lob.setLevel(lob.asks, upd.Rate, upd.Amount)
+	default:
+		lob.seq = upd.Seq
+		lob.publish(BookEvent{Symbol: lob.symbol, Seq: upd.Seq, Type: TradeEvent})
+		return true
+	}
+
+	lob.seq = upd.Seq
+	lob.publish(BookEvent{Symbol: lob.symbol, Seq: upd.Seq, Type: Delta})
+	return true
+}
+
+func (lob *LocalOrderBook) publish(ev BookEvent) {
+	select {
+	case lob.events <- ev:
+	default:
+		// slow consumer: drop rather than block the maintainer goroutine
+	}
+}
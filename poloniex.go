@@ -2,11 +2,7 @@
 package poloniex
 
 import (
-	"encoding/json"
-	"errors"
-	"fmt"
-	"strconv"
-	"strings"
+	"context"
 	"time"
 )
 
@@ -39,37 +35,16 @@ func (b *Poloniex) SetDebug(enable bool) {
 
 // GetTickers is used to get the ticker for all markets
 func (b *Poloniex) GetTickers() (tickers map[string]Ticker, err error) {
-	r, err := b.client.do("GET", "public?command=returnTicker", nil, false)
-	if err != nil {
-		return
-	}
-	if err = json.Unmarshal(r, &tickers); err != nil {
-		return
-	}
-	return
+	return b.GetTickersContext(context.Background())
 }
 
 // GetVolumes is used to get the volume for all markets
 func (b *Poloniex) GetVolumes() (vc VolumeCollection, err error) {
-	r, err := b.client.do("GET", "public?command=return24hVolume", nil, false)
-	if err != nil {
-		return
-	}
-	if err = json.Unmarshal(r, &vc); err != nil {
-		return
-	}
-	return
+	return b.GetVolumesContext(context.Background())
 }
 
 func (b *Poloniex) GetCurrencies() (currencies Currencies, err error) {
-	r, err := b.client.do("GET", "public?command=returnCurrencies", nil, false)
-	if err != nil {
-		return
-	}
-	if err = json.Unmarshal(r, &currencies.Pair); err != nil {
-		return
-	}
-	return
+	return b.GetCurrenciesContext(context.Background())
 }
 
 // GetOrderBook is used to get retrieve the orderbook for a given market
@@ -77,54 +52,14 @@ func (b *Poloniex) GetCurrencies() (currencies Currencies, err error) {
 // cat: bid, ask or both to identify the type of orderbook to return.
 // depth: how deep of an order book to retrieve
 func (b *Poloniex) GetOrderBook(market, cat string, depth int) (orderBook OrderBook, err error) {
-	// not implemented
-	if cat != "bid" && cat != "ask" && cat != "both" {
-		cat = "both"
-	}
-	if depth > 100 {
-		depth = 100
-	}
-	if depth < 1 {
-		depth = 1
-	}
-
-	r, err := b.client.do("GET", fmt.Sprintf("public?command=returnOrderBook&currencyPair=%s&depth=%d", strings.ToUpper(market), depth), nil, false)
-	if err != nil {
-		return
-	}
-	if err = json.Unmarshal(r, &orderBook); err != nil {
-		return
-	}
-	if orderBook.Error != "" {
-		err = errors.New(orderBook.Error)
-		return
-	}
-	return
+	return b.GetOrderBookContext(context.Background(), market, cat, depth)
 }
 
 // GetAllOrderBook is used to get retrieve the orderbook for all markets
 // cat: bid, ask or both to identify the type of orderbook to return.
 // depth: how deep of an order book to retrieve
 func (b *Poloniex) GetAllOrderBook(cat string, depth int) (orderBook map[string]OrderBook, err error) {
-	// not implemented
-	if cat != "bid" && cat != "ask" && cat != "both" {
-		cat = "both"
-	}
-	if depth > 100 {
-		depth = 100
-	}
-	if depth < 1 {
-		depth = 1
-	}
-
-	r, err := b.client.do("GET", fmt.Sprintf("public?command=returnOrderBook&currencyPair=all&depth=%d", depth), nil, false)
-	if err != nil {
-		return
-	}
-	if err = json.Unmarshal(r, &orderBook); err != nil {
-		return
-	}
-	return
+	return b.GetAllOrderBookContext(context.Background(), cat, depth)
 }
 
 // Returns candlestick chart data. Required GET parameters are "currencyPair",
@@ -133,22 +68,7 @@ func (b *Poloniex) GetAllOrderBook(cat string, depth int) (orderBook map[string]
 // UNIX timestamp format and used to specify the date range for the data
 // returned.
 func (b *Poloniex) ChartData(currencyPair string, period int, start, end time.Time) (candles []*CandleStick, err error) {
-	r, err := b.client.do("GET", fmt.Sprintf(
-		"public?command=returnChartData&currencyPair=%s&period=%d&start=%d&end=%d",
-		strings.ToUpper(currencyPair),
-		period,
-		start.Unix(),
-		end.Unix(),
-	), nil, false)
-	if err != nil {
-		return
-	}
-
-	if err = json.Unmarshal(r, &candles); err != nil {
-		return
-	}
-
-	return
+	return b.ChartDataContext(context.Background(), currencyPair, period, start, end)
 }
 
 // SubscribeOrderBook subscribes for trades and order book updates via WAMP.
@@ -190,129 +110,61 @@ func (b *Poloniex) SubscribeTicker(updatesCh chan<- TickerUpd, stopCh <-chan boo
 }
 
 func (b *Poloniex) GetBalances() (balances map[string]Balance, err error) {
-	balances = make(map[string]Balance)
-	r, err := b.client.doCommand("returnCompleteBalances", nil)
-	if err != nil {
-		return
-	}
-
-	if err = json.Unmarshal(r, &balances); err != nil {
-		return
-	}
-
-	return
+	return b.GetBalancesContext(context.Background())
 }
 
 func (b *Poloniex) GetTradeHistory(pair string, start uint32) (trades map[string][]Trade, err error) {
-	trades = make(map[string][]Trade)
-	r, err := b.client.doCommand("returnTradeHistory", map[string]string{"currencyPair": pair, "start": strconv.FormatUint(uint64(start), 10)})
-	if err != nil {
-		return
-	}
-
-	if pair == "all" {
-		if err = json.Unmarshal(r, &trades); err != nil {
-			return
-		}
-	} else {
-		var pairTrades []Trade
-		if err = json.Unmarshal(r, &pairTrades); err != nil {
-			return
-		}
-		trades[pair] = pairTrades
-	}
+	return b.GetTradeHistoryContext(context.Background(), pair, start)
+}
 
-	return
+func (b *Poloniex) GetDepositsWithdrawals(start uint32, end uint32) (deposits []Deposit, withdrawals []Withdrawal, err error) {
+	return b.GetDepositsWithdrawalsContext(context.Background(), start, end)
 }
 
-type responseDepositsWithdrawals struct {
-	Deposits    []Deposit    `json:"deposits"`
-	Withdrawals []Withdrawal `json:"withdrawals"`
+func (b *Poloniex) Buy(pair string, rate float64, amount float64, tradeType string) (TradeOrder, error) {
+	return b.BuyContext(context.Background(), pair, rate, amount, tradeType)
 }
 
-func (b *Poloniex) GetDepositsWithdrawals(start uint32, end uint32) (deposits []Deposit, withdrawals []Withdrawal, err error) {
-	deposits = make([]Deposit, 0)
-	withdrawals = make([]Withdrawal, 0)
-	r, err := b.client.doCommand("returnDepositsWithdrawals", map[string]string{"start": strconv.FormatUint(uint64(start), 10), "end": strconv.FormatUint(uint64(end), 10)})
-	if err != nil {
-		return
-	}
-	var response responseDepositsWithdrawals
-	if err = json.Unmarshal(r, &response); err != nil {
-		return
-	}
+func (b *Poloniex) Sell(pair string, rate float64, amount float64, tradeType string) (TradeOrder, error) {
+	return b.SellContext(context.Background(), pair, rate, amount, tradeType)
+}
 
-	return response.Deposits, response.Withdrawals, nil
+func (b *Poloniex) GetOpenOrders(pair string) (openOrders map[string][]OpenOrder, err error) {
+	return b.GetOpenOrdersContext(context.Background(), pair)
 }
 
-func (b *Poloniex) Buy(pair string, rate float64, amount float64, tradeType string) (TradeOrder, error) {
-	reqParams := map[string]string{
-		"currencyPair": pair, "rate": strconv.FormatFloat(rate, 'f', -1, 64),
-		"amount": strconv.FormatFloat(amount, 'f', -1, 64)}
-	if tradeType != "" {
-		reqParams[tradeType] = "1"
-	}
-	r, err := b.client.doCommand("buy", reqParams)
-	if err != nil {
-		return TradeOrder{}, err
-	}
-	var orderResponse TradeOrder
-	if err = json.Unmarshal(r, &orderResponse); err != nil {
-		return TradeOrder{}, err
-	}
+// CancelOrder cancels an open order.
+func (b *Poloniex) CancelOrder(orderNumber int64) (bool, error) {
+	return b.CancelOrderContext(context.Background(), orderNumber)
+}
 
-	return orderResponse, nil
+// MoveOrder cancels an order and places a new one at the given rate and
+// amount, keeping the same order type. Set amount to 0 to keep the order's
+// remaining amount. postOnly and immediateOrCancel mirror the flags
+// accepted by Buy/Sell; at most one of them should be true.
+func (b *Poloniex) MoveOrder(orderNumber int64, rate, amount float64, postOnly, immediateOrCancel bool) (TradeOrder, error) {
+	return b.MoveOrderContext(context.Background(), orderNumber, rate, amount, postOnly, immediateOrCancel)
 }
 
-func (b *Poloniex) Sell(pair string, rate float64, amount float64, tradeType string) (TradeOrder, error) {
-	reqParams := map[string]string{
-		"currencyPair": pair, "rate": strconv.FormatFloat(rate, 'f', -1, 64),
-		"amount": strconv.FormatFloat(amount, 'f', -1, 64)}
-	if tradeType != "" {
-		reqParams[tradeType] = "1"
-	}
-	r, err := b.client.doCommand("sell", reqParams)
-	if err != nil {
-		return TradeOrder{}, err
-	}
-	var orderResponse TradeOrder
-	if err = json.Unmarshal(r, &orderResponse); err != nil {
-		return TradeOrder{}, err
-	}
+// Withdraw withdraws a currency to the given address. paymentID is required
+// for currencies that use a payment/destination tag (e.g. XMR, XRP) and
+// should be left empty otherwise.
+func (b *Poloniex) Withdraw(currency, address string, amount float64, paymentID string) (string, error) {
+	return b.WithdrawContext(context.Background(), currency, address, amount, paymentID)
+}
 
-	return orderResponse, nil
+// GenerateNewAddress generates a new deposit address for the given
+// currency.
+func (b *Poloniex) GenerateNewAddress(currency string) (string, error) {
+	return b.GenerateNewAddressContext(context.Background(), currency)
 }
 
-func (b *Poloniex) GetOpenOrders(pair string) (openOrders map[string][]OpenOrder, err error) {
-	openOrders = make(map[string][]OpenOrder)
-	r, err := b.client.doCommand("returnOpenOrders", map[string]string{"currencyPair": pair})
-	if err != nil {
-		return
-	}
-	if pair == "all" {
-		if err = json.Unmarshal(r, &openOrders); err != nil {
-			return
-		}
-	} else {
-		var onePairOrders []OpenOrder
-		if err = json.Unmarshal(r, &onePairOrders); err != nil {
-			return
-		}
-		openOrders[pair] = onePairOrders
-	}
-	return
+// GetDepositAddresses returns the deposit addresses already generated for
+// this account, keyed by currency.
+func (b *Poloniex) GetDepositAddresses() (addresses map[string]string, err error) {
+	return b.GetDepositAddressesContext(context.Background())
 }
 
 func (b *Poloniex) GetFees() (Fees, error) {
-	reqParams := map[string]string{}
-	r, err := b.client.doCommand("returnFeeInfo", reqParams)
-	if err != nil {
-		return Fees{}, err
-	}
-	var orderResponse Fees
-	if err = json.Unmarshal(r, &orderResponse); err != nil {
-		return Fees{}, err
-	}
-
-	return orderResponse, nil
+	return b.GetFeesContext(context.Background())
 }
@@ -0,0 +1,251 @@
+package poloniex
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"golang.org/x/net/websocket"
+)
+
+// WAMP v1 message type IDs, as sent over Poloniex's push API.
+const (
+	wampWelcome     = 0
+	wampPrefix      = 1
+	wampCall        = 2
+	wampCallResult  = 3
+	wampCallError   = 4
+	wampSubscribe   = 5
+	wampUnsubscribe = 6
+	wampPublish     = 7
+	wampEvent       = 8
+)
+
+// wsHandler processes a single EVENT payload received for the subscribed
+// channel. seq is the sequence number Poloniex attaches to order book
+// updates; it is unset (0) for channels that don't carry one, such as
+// ticker.
+type wsHandler func(payload json.RawMessage, seq int)
+
+// wampOBEntry is a single entry of the array Poloniex sends as the EVENT
+// payload for a currency pair channel.
+type wampOBEntry struct {
+	Type string `json:"type"` // "orderBookModify", "orderBookRemove" or "newTrade"
+	Data struct {
+		Type   string          `json:"type"` // "bid" or "ask"
+		Rate   decimal.Decimal `json:"rate"`
+		Amount decimal.Decimal `json:"amount"`
+	} `json:"data"`
+}
+
+// makeOBookSubHandler returns a wsHandler that turns the order book/trade
+// channel's EVENT payload into MarketUpd values on updatesCh.
+func makeOBookSubHandler(updatesCh chan<- MarketUpd) wsHandler {
+	return func(payload json.RawMessage, seq int) {
+		var entries []wampOBEntry
+		if err := json.Unmarshal(payload, &entries); err != nil {
+			return
+		}
+
+		now := time.Now()
+		for _, e := range entries {
+			upd := MarketUpd{Seq: seq, Time: now}
+			switch e.Type {
+			case "orderBookModify":
+				upd.Type = e.Data.Type
+				upd.Rate = e.Data.Rate
+				upd.Amount = e.Data.Amount
+			case "orderBookRemove":
+				upd.Type = e.Data.Type
+				upd.Rate = e.Data.Rate
+				upd.Amount = decimal.Zero
+			case "newTrade":
+				upd.Type = "trade"
+				upd.Rate = e.Data.Rate
+				upd.Amount = e.Data.Amount
+			default:
+				continue
+			}
+
+			select {
+			case updatesCh <- upd:
+			default:
+				// slow consumer: drop rather than block the ws read loop
+			}
+		}
+	}
+}
+
+// makeTickerSubHandler returns a wsHandler that turns the "ticker" channel's
+// EVENT payload, a flat array of fields, into TickerUpd values on
+// updatesCh.
+func makeTickerSubHandler(updatesCh chan<- TickerUpd) wsHandler {
+	return func(payload json.RawMessage, _ int) {
+		var fields []string
+		if err := json.Unmarshal(payload, &fields); err != nil || len(fields) < 10 {
+			return
+		}
+
+		parse := func(s string) decimal.Decimal {
+			d, _ := decimal.NewFromString(s)
+			return d
+		}
+		upd := TickerUpd{
+			Pair:          fields[0],
+			Last:          parse(fields[1]),
+			LowestAsk:     parse(fields[2]),
+			HighestBid:    parse(fields[3]),
+			PercentChange: parse(fields[4]),
+			BaseVolume:    parse(fields[5]),
+			QuoteVolume:   parse(fields[6]),
+			IsFrozen:      fields[7] == "1",
+			High24hr:      parse(fields[8]),
+			Low24hr:       parse(fields[9]),
+		}
+
+		select {
+		case updatesCh <- upd:
+		default:
+		}
+	}
+}
+
+// wsDispatch parses a raw WAMP frame and, if it is an EVENT for channel,
+// invokes handler with its payload and sequence number.
+func wsDispatch(raw []byte, channel string, handler wsHandler) {
+	var envelope []json.RawMessage
+	if err := json.Unmarshal(raw, &envelope); err != nil || len(envelope) < 3 {
+		return
+	}
+
+	var msgType int
+	if err := json.Unmarshal(envelope[0], &msgType); err != nil || msgType != wampEvent {
+		return
+	}
+
+	var topic string
+	if err := json.Unmarshal(envelope[1], &topic); err != nil || topic != channel {
+		return
+	}
+
+	var seq int
+	if len(envelope) > 3 {
+		_ = json.Unmarshal(envelope[3], &seq)
+	}
+	handler(envelope[2], seq)
+}
+
+// wsRegister tracks conn so wsReset/close can reach it later.
+func (c *client) wsRegister(conn *websocket.Conn) {
+	c.wsMu.Lock()
+	defer c.wsMu.Unlock()
+	if c.wsConns == nil {
+		c.wsConns = make(map[*websocket.Conn]bool)
+	}
+	c.wsConns[conn] = true
+}
+
+func (c *client) wsUnregister(conn *websocket.Conn) {
+	c.wsMu.Lock()
+	defer c.wsMu.Unlock()
+	delete(c.wsConns, conn)
+}
+
+// wsConnect dials the WAMP feed, subscribes to channel, and dispatches
+// EVENT messages to handler until stopCh says to stop or reconnect, or the
+// connection fails.
+//
+// It returns cont=false once the caller should stop looping (stopCh was
+// closed, or received true, or the client was closed via close()), in
+// which case err is the reason, if any. It returns cont=true when the
+// caller should redial, either because stopCh received false or because
+// the connection dropped and the client hasn't been closed.
+func (c *client) wsConnect(channel string, handler wsHandler, stopCh <-chan bool) (cont bool, err error) {
+	c.wsMu.Lock()
+	closed := c.wsClosed
+	c.wsMu.Unlock()
+	if closed {
+		return false, nil
+	}
+
+	conn, err := websocket.Dial(API_WS, "", API_BASE)
+	if err != nil {
+		return true, err
+	}
+	c.wsRegister(conn)
+	defer func() {
+		conn.Close()
+		c.wsUnregister(conn)
+	}()
+
+	if err := websocket.JSON.Send(conn, []interface{}{wampSubscribe, channel}); err != nil {
+		return true, err
+	}
+
+	msgCh := make(chan []byte, 16)
+	readErrCh := make(chan error, 1)
+	go func() {
+		for {
+			var raw []byte
+			if err := websocket.Message.Receive(conn, &raw); err != nil {
+				readErrCh <- err
+				return
+			}
+			select {
+			case msgCh <- raw:
+			default:
+				// slow consumer: drop rather than block the socket read
+			}
+		}
+	}()
+
+	for {
+		select {
+		case raw := <-msgCh:
+			wsDispatch(raw, channel, handler)
+		case err := <-readErrCh:
+			c.wsMu.Lock()
+			closed := c.wsClosed
+			c.wsMu.Unlock()
+			return !closed, err
+		case stop, ok := <-stopCh:
+			if !ok || stop {
+				return false, nil
+			}
+			return true, nil
+		}
+	}
+}
+
+// wsReset closes every active WS connection, forcing their wsConnect calls
+// to redial.
+func (c *client) wsReset() error {
+	c.wsMu.Lock()
+	conns := make([]*websocket.Conn, 0, len(c.wsConns))
+	for conn := range c.wsConns {
+		conns = append(conns, conn)
+	}
+	c.wsMu.Unlock()
+
+	for _, conn := range conns {
+		conn.Close()
+	}
+	return nil
+}
+
+// close marks the client as closed and tears down every active WS
+// connection, so their wsConnect calls return rather than redial.
+func (c *client) close() error {
+	c.wsMu.Lock()
+	c.wsClosed = true
+	conns := make([]*websocket.Conn, 0, len(c.wsConns))
+	for conn := range c.wsConns {
+		conns = append(conns, conn)
+	}
+	c.wsMu.Unlock()
+
+	for _, conn := range conns {
+		conn.Close()
+	}
+	return nil
+}